@@ -27,11 +27,14 @@ ADR_GOV_GOV := g109hw2sc704c2vnxy2y89vjyqluvzra8rhp4l8w
 ADDR_LAUNCHPAD := g1x6r75sxlkp9zfqufew0vcuq9sfclsq8uaqkru9
 ADDR_GNS := g13ffa5r3mqfxu3s7ejl02scq9536wt6c2t789dm
 ADDR_GNFT := g1mclfz2dn4lnez0lcjwgz67hh72rdafjmufvfmw
+ADDR_LIMIT_ORDER := g1xmauqrw6ca9pugaelp0t32sn3wv0tnfwff2xfg
+ADDR_BUYBACK := g17u78s58rlslxpdz0lpvgyw3r5xfy65kz25uvld
 
 # User Addresses (used for test scripts)
 ADDR_GNOSWAP := g1lmvrrrr4er2us84h2732sru76c9zl2nvknha8c
 ADDR_ADMIN := g1jg8mtutu9khhfwc4nxmuhcpftf0pajdhfvsqf5
 ADDR_TEST := g1mjqcxzek8yacgcvnqfkj0dck67wdyhqlfp9unr
+ADDR_VAULT_STRATEGIST := $(ADDR_ADMIN)
 
 # Test User Addresses
 ADDR_TEST_ADMIN := g1lmvrrrr4er2us84h2732sru76c9zl2nvknha8c
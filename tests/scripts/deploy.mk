@@ -14,7 +14,7 @@ patch-admin-address:
 init: deploy-test-tokens deploy-gnoswap
 
 .PHONY: deploy-gnoswap
-init: deploy-libraries deploy-base-contracts deploy-gnoswap-realms deploy-gnoswap-impl-v1
+init: deploy-libraries deploy-base-contracts deploy-gnoswap-realms deploy-gnoswap-impl-v1 setup-gnoswap-roles
 
 # All realms under contract/r/gnoswap/test_token/ (each subdir with gnomod.toml)
 TEST_TOKEN_NAMES := atom atone btc dai eth photon sol trx usdc usdt
@@ -29,11 +29,15 @@ deploy-libraries: deploy-uint256 deploy-int256 deploy-consts deploy-rbac deploy-
 deploy-base-contracts: deploy-access deploy-rbac-realm deploy-halt-realm deploy-referral deploy-gns deploy-emission deploy-common deploy-community_pool deploy-gnft deploy-xgns
 
 .PHONY: deploy-gnoswap-realms
-deploy-gnoswap-realms: deploy-protocol_fee deploy-pool deploy-position deploy-router deploy-staker deploy-gov-staker deploy-governance deploy-launchpad
+deploy-gnoswap-realms: deploy-protocol_fee deploy-pool deploy-position deploy-limit_order deploy-vault deploy-router deploy-buyback deploy-staker deploy-gov-staker deploy-governance deploy-launchpad
 
 .PHONY: deploy-gnoswap-impl-v1
 deploy-gnoswap-impl-v1: deploy-protocol_fee-v1 deploy-pool-v1 deploy-position-v1 deploy-router-v1 deploy-staker-v1 deploy-gov-staker-v1 deploy-governance-v1 deploy-launchpad-v1
 
+# Roles registered at runtime for realms that are not part of the system roles
+.PHONY: setup-gnoswap-roles
+setup-gnoswap-roles: setup-limit_order setup-vault setup-buyback
+
 deploy-gnsmath:
 	$(info ************ deploy gnsmath ************)
 	@echo "" | gnokey maketx addpkg -pkgdir $(ROOT_DIR)/contract/p/gnoswap/gnsmath -pkgpath gno.land/p/gnoswap/gnsmath -insecure-password-stdin=true -remote $(GNOLAND_RPC_URL) -broadcast=true -chainid $(CHAINID) -gas-fee 22457ugnot -gas-wanted 22457000 -memo "" gnoswap_admin
@@ -154,11 +158,42 @@ deploy-halt-realm:
 	@echo "" | gnokey maketx addpkg -pkgdir $(ROOT_DIR)/contract/r/gnoswap/halt -pkgpath gno.land/r/gnoswap/halt -insecure-password-stdin=true -remote $(GNOLAND_RPC_URL) -broadcast=true -chainid $(CHAINID) -gas-fee 22679ugnot -gas-wanted 22679000 -memo "" gnoswap_admin
 	@echo
 
+deploy-limit_order:
+	$(info ************ deploy limit_order ************)
+	@echo "" | gnokey maketx addpkg -pkgdir $(ROOT_DIR)/contract/r/gnoswap/limit_order -pkgpath gno.land/r/gnoswap/limit_order -insecure-password-stdin=true -remote $(GNOLAND_RPC_URL) -broadcast=true -chainid $(CHAINID) -gas-fee 30000ugnot -gas-wanted 30000000 -memo "" gnoswap_admin
+	@echo
+
+setup-limit_order:
+	$(info ************ register limit_order role and subscribe to tick crosses ************)
+	@echo "" | gnokey maketx call -pkgpath gno.land/r/gnoswap/rbac -func RegisterRole -args "limit_order" -args $(ADDR_LIMIT_ORDER) -insecure-password-stdin=true -remote $(GNOLAND_RPC_URL) -broadcast=true -chainid $(CHAINID) -gas-fee 1000000ugnot -gas-wanted 1000000000 -memo "" gnoswap_admin
+	@echo "" | gnokey maketx call -pkgpath gno.land/r/gnoswap/limit_order -func SubscribeTickCross -insecure-password-stdin=true -remote $(GNOLAND_RPC_URL) -broadcast=true -chainid $(CHAINID) -gas-fee 1000000ugnot -gas-wanted 1000000000 -memo "" gnoswap_admin
+	@echo
+
+deploy-vault:
+	$(info ************ deploy vault ************)
+	@echo "" | gnokey maketx addpkg -pkgdir $(ROOT_DIR)/contract/r/gnoswap/vault -pkgpath gno.land/r/gnoswap/vault -insecure-password-stdin=true -remote $(GNOLAND_RPC_URL) -broadcast=true -chainid $(CHAINID) -gas-fee 30000ugnot -gas-wanted 30000000 -memo "" gnoswap_admin
+	@echo
+
+setup-vault:
+	$(info ************ register vault_strategist role ************)
+	@echo "" | gnokey maketx call -pkgpath gno.land/r/gnoswap/rbac -func RegisterRole -args "vault_strategist" -args $(ADDR_VAULT_STRATEGIST) -insecure-password-stdin=true -remote $(GNOLAND_RPC_URL) -broadcast=true -chainid $(CHAINID) -gas-fee 1000000ugnot -gas-wanted 1000000000 -memo "" gnoswap_admin
+	@echo
+
 deploy-router:
 	$(info ************ deploy router ************)
 	@echo "" | gnokey maketx addpkg -pkgdir $(ROOT_DIR)/contract/r/gnoswap/router -pkgpath gno.land/r/gnoswap/router -insecure-password-stdin=true -remote $(GNOLAND_RPC_URL) -broadcast=true -chainid $(CHAINID) -gas-fee 19340ugnot -gas-wanted 19340000 -memo "" gnoswap_admin
 	@echo
 
+deploy-buyback:
+	$(info ************ deploy buyback ************)
+	@echo "" | gnokey maketx addpkg -pkgdir $(ROOT_DIR)/contract/r/gnoswap/buyback -pkgpath gno.land/r/gnoswap/buyback -insecure-password-stdin=true -remote $(GNOLAND_RPC_URL) -broadcast=true -chainid $(CHAINID) -gas-fee 25000ugnot -gas-wanted 25000000 -memo "" gnoswap_admin
+	@echo
+
+setup-buyback:
+	$(info ************ register buyback role ************)
+	@echo "" | gnokey maketx call -pkgpath gno.land/r/gnoswap/rbac -func RegisterRole -args "buyback" -args $(ADDR_BUYBACK) -insecure-password-stdin=true -remote $(GNOLAND_RPC_URL) -broadcast=true -chainid $(CHAINID) -gas-fee 1000000ugnot -gas-wanted 1000000000 -memo "" gnoswap_admin
+	@echo
+
 deploy-staker:
 	$(info ************ deploy staker ************)
 	@echo "" | gnokey maketx addpkg -pkgdir $(ROOT_DIR)/contract/r/gnoswap/staker -pkgpath gno.land/r/gnoswap/staker -insecure-password-stdin=true -remote $(GNOLAND_RPC_URL) -broadcast=true -chainid $(CHAINID) -gas-fee 64623ugnot -gas-wanted 64623000 -memo "" gnoswap_admin
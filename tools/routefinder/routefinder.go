@@ -0,0 +1,401 @@
+// Package routefinder searches GnoSwap pools for the routes and split that give
+// the best price for a swap, off-chain.
+//
+// It implements the same search as the router's FindBestRoute realm function:
+// candidate routes are enumerated from a token graph of the pools, shortest first,
+// and the amount is assigned to them in fixed percentage steps, each step going to
+// the route whose simulated result improves the most. Swaps are simulated through
+// a Quoter, typically backed by the pool realm's DrySwap query.
+//
+// The result can be passed to the router swap functions as routeArr and quoteArr.
+package routefinder
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// PoolSeparator joins the hops of a multi-hop route.
+const PoolSeparator = "*POOL*"
+
+// Router limits, mirrored from the router realm.
+const (
+	MaxHops   = 3
+	MaxRoutes = 7
+)
+
+var (
+	ErrInvalidPoolPath = errors.New("routefinder: invalid pool path")
+	ErrInvalidAmount   = errors.New("routefinder: amount must be positive")
+	ErrInvalidSwapType = errors.New("routefinder: invalid swap type")
+	ErrInvalidOptions  = errors.New("routefinder: invalid options")
+	ErrSameToken       = errors.New("routefinder: cannot swap same token")
+	ErrNoRoute         = errors.New("routefinder: no route found")
+)
+
+// SwapType is the router swap type.
+type SwapType string
+
+const (
+	ExactIn  SwapType = "EXACT_IN"
+	ExactOut SwapType = "EXACT_OUT"
+)
+
+// Pool identifies a pool by its sorted token paths and fee tier.
+type Pool struct {
+	Token0 string
+	Token1 string
+	Fee    uint32
+}
+
+// ParsePoolPath parses a pool path of the form "token0:token1:fee".
+func ParsePoolPath(poolPath string) (Pool, error) {
+	parts := strings.Split(poolPath, ":")
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" {
+		return Pool{}, fmt.Errorf("%w: %q", ErrInvalidPoolPath, poolPath)
+	}
+
+	fee, err := strconv.ParseUint(parts[2], 10, 32)
+	if err != nil {
+		return Pool{}, fmt.Errorf("%w: %q: %v", ErrInvalidPoolPath, poolPath, err)
+	}
+
+	return Pool{Token0: parts[0], Token1: parts[1], Fee: uint32(fee)}, nil
+}
+
+// Path returns the pool path of p.
+func (p Pool) Path() string {
+	return p.Token0 + ":" + p.Token1 + ":" + strconv.FormatUint(uint64(p.Fee), 10)
+}
+
+// Quoter simulates a swap in a single pool without changing its state.
+//
+// It has the semantics of the pool realm's DrySwap: amountSpecified is an exact
+// input when positive and an exact output when negative, and the returned deltas
+// are positive for the token the pool receives and negative for the token it sends.
+type Quoter interface {
+	DrySwap(token0, token1 string, fee uint32, zeroForOne bool, amountSpecified int64) (amount0, amount1 int64, ok bool)
+}
+
+// QuoterFunc adapts a function to the Quoter interface.
+type QuoterFunc func(token0, token1 string, fee uint32, zeroForOne bool, amountSpecified int64) (int64, int64, bool)
+
+// DrySwap calls f.
+func (f QuoterFunc) DrySwap(token0, token1 string, fee uint32, zeroForOne bool, amountSpecified int64) (int64, int64, bool) {
+	return f(token0, token1, fee, zeroForOne, amountSpecified)
+}
+
+// Options tunes the search.
+type Options struct {
+	MaxHops       int // longest route to consider, 1~MaxHops
+	MaxRoutes     int // largest split, 1~MaxRoutes
+	MaxCandidates int // routes simulated at most
+	SplitStep     int // percentage assigned per step, must divide 100
+}
+
+// DefaultOptions returns the options used by the router realm.
+func DefaultOptions() Options {
+	return Options{
+		MaxHops:       MaxHops,
+		MaxRoutes:     MaxRoutes,
+		MaxCandidates: 10,
+		SplitStep:     10,
+	}
+}
+
+func (o Options) validate() error {
+	switch {
+	case o.MaxHops < 1 || o.MaxHops > MaxHops:
+		return fmt.Errorf("%w: MaxHops(%d) must be 1~%d", ErrInvalidOptions, o.MaxHops, MaxHops)
+	case o.MaxRoutes < 1 || o.MaxRoutes > MaxRoutes:
+		return fmt.Errorf("%w: MaxRoutes(%d) must be 1~%d", ErrInvalidOptions, o.MaxRoutes, MaxRoutes)
+	case o.MaxCandidates < 1:
+		return fmt.Errorf("%w: MaxCandidates(%d) must be positive", ErrInvalidOptions, o.MaxCandidates)
+	case o.SplitStep < 1 || o.SplitStep > 100 || 100%o.SplitStep != 0:
+		return fmt.Errorf("%w: SplitStep(%d) must divide 100", ErrInvalidOptions, o.SplitStep)
+	}
+
+	return nil
+}
+
+// Result is the best split found for a swap.
+type Result struct {
+	Routes    []string // routes in swap direction, "tokenIn:tokenOut:fee*POOL*..."
+	Quotes    []int    // percentage of the amount per route, summing to 100
+	AmountIn  int64    // simulated input, before the router fee
+	AmountOut int64    // simulated output, before the router fee
+}
+
+// RouteArr returns the routes in the router's routeArr format.
+func (r *Result) RouteArr() string {
+	return strings.Join(r.Routes, ",")
+}
+
+// QuoteArr returns the split in the router's quoteArr format.
+func (r *Result) QuoteArr() string {
+	quotes := make([]string, len(r.Quotes))
+	for i, quote := range r.Quotes {
+		quotes[i] = strconv.Itoa(quote)
+	}
+
+	return strings.Join(quotes, ",")
+}
+
+type edge struct {
+	tokenOut string
+	fee      uint32
+}
+
+type graph map[string][]edge
+
+func newGraph(pools []Pool) graph {
+	g := make(graph)
+	for _, pool := range pools {
+		g[pool.Token0] = append(g[pool.Token0], edge{tokenOut: pool.Token1, fee: pool.Fee})
+		g[pool.Token1] = append(g[pool.Token1], edge{tokenOut: pool.Token0, fee: pool.Fee})
+	}
+
+	return g
+}
+
+// Paths returns the routes from tokenIn to tokenOut through pools with at most
+// maxHops hops, shortest first, without visiting a token twice.
+// At most limit routes are returned.
+func Paths(pools []Pool, tokenIn, tokenOut string, maxHops, limit int) []string {
+	g := newGraph(pools)
+	result := make([]string, 0)
+
+	for hops := 1; hops <= maxHops && len(result) < limit; hops++ {
+		visited := map[string]bool{tokenIn: true}
+		g.walk(tokenIn, tokenOut, hops, visited, nil, &result, limit)
+	}
+
+	return result
+}
+
+func (g graph) walk(token, tokenOut string, hopsLeft int, visited map[string]bool, route []string, result *[]string, limit int) {
+	for _, e := range g[token] {
+		if len(*result) >= limit {
+			return
+		}
+
+		step := token + ":" + e.tokenOut + ":" + strconv.FormatUint(uint64(e.fee), 10)
+
+		if hopsLeft == 1 {
+			if e.tokenOut == tokenOut {
+				*result = append(*result, strings.Join(append(route, step), PoolSeparator))
+			}
+			continue
+		}
+
+		if visited[e.tokenOut] || e.tokenOut == tokenOut {
+			continue
+		}
+
+		visited[e.tokenOut] = true
+		g.walk(e.tokenOut, tokenOut, hopsLeft-1, visited, append(route, step), result, limit)
+		visited[e.tokenOut] = false
+	}
+}
+
+// routeAmounts is a simulated route: amounts entering the first and leaving the last hop.
+type routeAmounts struct {
+	amountIn  int64
+	amountOut int64
+}
+
+// hop is a route step in swap direction.
+type hop struct {
+	tokenIn  string
+	tokenOut string
+	fee      uint32
+}
+
+// swap simulates h with q and returns the amounts entering and leaving the pool.
+func (h hop) swap(q Quoter, amountSpecified int64) (int64, int64, bool) {
+	zeroForOne := h.tokenIn < h.tokenOut
+	token0, token1 := h.tokenIn, h.tokenOut
+	if !zeroForOne {
+		token0, token1 = token1, token0
+	}
+
+	amount0, amount1, ok := q.DrySwap(token0, token1, h.fee, zeroForOne, amountSpecified)
+	if !ok {
+		return 0, 0, false
+	}
+
+	if zeroForOne {
+		return amount0, -amount1, amount0 > 0 && amount1 < 0
+	}
+
+	return amount1, -amount0, amount1 > 0 && amount0 < 0
+}
+
+// simulateRoute swaps amount through every hop of route. Exact in routes are
+// simulated forward from the input, exact out routes backward from the output.
+func simulateRoute(q Quoter, swapType SwapType, route string, amount int64) (routeAmounts, bool) {
+	paths := strings.Split(route, PoolSeparator)
+	hops := make([]hop, len(paths))
+	for i, path := range paths {
+		// routes share the pool path format, in swap direction
+		parsed, err := ParsePoolPath(path)
+		if err != nil {
+			return routeAmounts{}, false
+		}
+		hops[i] = hop{tokenIn: parsed.Token0, tokenOut: parsed.Token1, fee: parsed.Fee}
+	}
+
+	var result routeAmounts
+	if swapType == ExactIn {
+		result.amountIn = amount
+		for _, h := range hops {
+			_, out, ok := h.swap(q, amount)
+			if !ok {
+				return routeAmounts{}, false
+			}
+			amount = out
+		}
+		result.amountOut = amount
+
+		return result, true
+	}
+
+	result.amountOut = amount
+	for i := len(hops) - 1; i >= 0; i-- {
+		in, _, ok := hops[i].swap(q, -amount)
+		if !ok {
+			return routeAmounts{}, false
+		}
+		amount = in
+	}
+	result.amountIn = amount
+
+	return result, true
+}
+
+// percentOf returns floor(amount * percent / 100) without overflowing.
+func percentOf(amount int64, percent int) int64 {
+	return amount/100*int64(percent) + amount%100*int64(percent)/100
+}
+
+type candidate struct {
+	route   string
+	steps   int
+	results map[int]*routeAmounts // by number of steps, nil if unswappable
+}
+
+func (c *candidate) at(q Quoter, swapType SwapType, amount int64, splitStep, steps int) *routeAmounts {
+	if result, ok := c.results[steps]; ok {
+		return result
+	}
+
+	var result *routeAmounts
+	if stepAmount := percentOf(amount, splitStep*steps); stepAmount > 0 {
+		if simulated, ok := simulateRoute(q, swapType, c.route, stepAmount); ok {
+			result = &simulated
+		}
+	}
+
+	c.results[steps] = result
+	return result
+}
+
+func (c *candidate) marginalGain(q Quoter, swapType SwapType, amount int64, splitStep int) (int64, bool) {
+	next := c.at(q, swapType, amount, splitStep, c.steps+1)
+	if next == nil {
+		return 0, false
+	}
+
+	var current routeAmounts
+	if c.steps > 0 {
+		result := c.at(q, swapType, amount, splitStep, c.steps)
+		if result == nil {
+			return 0, false
+		}
+		current = *result
+	}
+
+	if swapType == ExactOut {
+		return current.amountIn - next.amountIn, true
+	}
+
+	return next.amountOut - current.amountOut, true
+}
+
+// FindBestRoute returns the routes and split through pools that give the best
+// price for swapping amount of tokenIn to tokenOut, simulated with q.
+//
+// amount is the input for ExactIn swaps and the output for ExactOut swaps.
+// Routes are simulated independently, so candidates sharing a pool may be quoted
+// optimistically; re-quote the result with the router's QuoteSwapRoute before
+// setting slippage limits. Exact out amounts do not include the router fee.
+func FindBestRoute(q Quoter, pools []Pool, tokenIn, tokenOut string, amount int64, swapType SwapType, opts Options) (*Result, error) {
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+
+	if swapType != ExactIn && swapType != ExactOut {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidSwapType, swapType)
+	}
+
+	if amount <= 0 {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidAmount, amount)
+	}
+
+	if tokenIn == tokenOut {
+		return nil, fmt.Errorf("%w: %s", ErrSameToken, tokenIn)
+	}
+
+	routes := Paths(pools, tokenIn, tokenOut, opts.MaxHops, opts.MaxCandidates)
+	candidates := make([]*candidate, len(routes))
+	for i, route := range routes {
+		candidates[i] = &candidate{route: route, results: make(map[int]*routeAmounts)}
+	}
+
+	used := 0
+	for step := 0; step < 100/opts.SplitStep; step++ {
+		var best *candidate
+		var bestGain int64
+
+		for _, c := range candidates {
+			if c.steps == 0 && used >= opts.MaxRoutes {
+				continue
+			}
+
+			gain, ok := c.marginalGain(q, swapType, amount, opts.SplitStep)
+			if !ok {
+				continue
+			}
+
+			if best == nil || gain > bestGain {
+				best, bestGain = c, gain
+			}
+		}
+
+		if best == nil {
+			return nil, fmt.Errorf("%w: %d %s to %s", ErrNoRoute, amount, tokenIn, tokenOut)
+		}
+
+		if best.steps == 0 {
+			used++
+		}
+		best.steps++
+	}
+
+	result := &Result{}
+	for _, c := range candidates {
+		if c.steps == 0 {
+			continue
+		}
+
+		simulated := c.results[c.steps]
+		result.Routes = append(result.Routes, c.route)
+		result.Quotes = append(result.Quotes, c.steps*opts.SplitStep)
+		result.AmountIn += simulated.amountIn
+		result.AmountOut += simulated.amountOut
+	}
+
+	return result, nil
+}
@@ -0,0 +1,245 @@
+package routefinder
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// constantProduct quotes swaps against x*y=k reserves, without fees.
+type constantProduct map[string][2]int64
+
+func (c constantProduct) DrySwap(token0, token1 string, fee uint32, zeroForOne bool, amountSpecified int64) (int64, int64, bool) {
+	reserves, ok := c[Pool{Token0: token0, Token1: token1, Fee: fee}.Path()]
+	if !ok || amountSpecified == 0 {
+		return 0, 0, false
+	}
+
+	reserveIn, reserveOut := reserves[0], reserves[1]
+	if !zeroForOne {
+		reserveIn, reserveOut = reserveOut, reserveIn
+	}
+
+	var amountIn, amountOut int64
+	if amountSpecified > 0 {
+		amountIn = amountSpecified
+		amountOut = reserveOut * amountIn / (reserveIn + amountIn)
+	} else {
+		amountOut = -amountSpecified
+		if amountOut >= reserveOut {
+			return 0, 0, false
+		}
+		amountIn = reserveIn*amountOut/(reserveOut-amountOut) + 1
+	}
+
+	if zeroForOne {
+		return amountIn, -amountOut, true
+	}
+
+	return -amountOut, amountIn, true
+}
+
+func TestParsePoolPath(t *testing.T) {
+	pool, err := ParsePoolPath("gno.land/r/onbloc/bar.BAR:gno.land/r/onbloc/baz.BAZ:3000")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := Pool{Token0: "gno.land/r/onbloc/bar.BAR", Token1: "gno.land/r/onbloc/baz.BAZ", Fee: 3000}
+	if pool != expected {
+		t.Fatalf("got %+v, want %+v", pool, expected)
+	}
+
+	if pool.Path() != "gno.land/r/onbloc/bar.BAR:gno.land/r/onbloc/baz.BAZ:3000" {
+		t.Fatalf("unexpected path %s", pool.Path())
+	}
+
+	for _, invalid := range []string{"", "a:b", "a:b:fee", ":b:500", "a:b:500:1"} {
+		if _, err := ParsePoolPath(invalid); !errors.Is(err, ErrInvalidPoolPath) {
+			t.Errorf("ParsePoolPath(%q) error = %v, want ErrInvalidPoolPath", invalid, err)
+		}
+	}
+}
+
+func TestPaths(t *testing.T) {
+	pools := []Pool{
+		{Token0: "a", Token1: "b", Fee: 500},
+		{Token0: "a", Token1: "c", Fee: 3000},
+		{Token0: "a", Token1: "d", Fee: 500},
+		{Token0: "b", Token1: "d", Fee: 500},
+		{Token0: "b", Token1: "c", Fee: 100},
+	}
+
+	tests := []struct {
+		name     string
+		tokenIn  string
+		tokenOut string
+		maxHops  int
+		limit    int
+		expected []string
+	}{
+		{
+			name:     "direct pool only",
+			tokenIn:  "a",
+			tokenOut: "d",
+			maxHops:  1,
+			limit:    10,
+			expected: []string{"a:d:500"},
+		},
+		{
+			name:     "shortest routes first",
+			tokenIn:  "a",
+			tokenOut: "d",
+			maxHops:  3,
+			limit:    10,
+			expected: []string{
+				"a:d:500",
+				"a:b:500*POOL*b:d:500",
+				"a:c:3000*POOL*c:b:100*POOL*b:d:500",
+			},
+		},
+		{
+			name:     "limit",
+			tokenIn:  "a",
+			tokenOut: "d",
+			maxHops:  3,
+			limit:    2,
+			expected: []string{"a:d:500", "a:b:500*POOL*b:d:500"},
+		},
+		{
+			name:     "no route",
+			tokenIn:  "a",
+			tokenOut: "e",
+			maxHops:  3,
+			limit:    10,
+			expected: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			paths := Paths(pools, tt.tokenIn, tt.tokenOut, tt.maxHops, tt.limit)
+			if !reflect.DeepEqual(paths, tt.expected) {
+				t.Fatalf("got %v, want %v", paths, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFindBestRoute(t *testing.T) {
+	pools := []Pool{
+		{Token0: "a", Token1: "b", Fee: 500},
+		{Token0: "a", Token1: "b", Fee: 3000},
+		{Token0: "a", Token1: "c", Fee: 500},
+		{Token0: "b", Token1: "c", Fee: 500},
+	}
+
+	quoter := constantProduct{
+		"a:b:500":  {1_000_000, 1_000_000},
+		"a:b:3000": {1_000_000, 1_000_000},
+		"a:c:500":  {10_000_000, 10_000_000},
+		"b:c:500":  {10_000_000, 10_000_000},
+	}
+
+	t.Run("small swap takes the single best route", func(t *testing.T) {
+		result, err := FindBestRoute(quoter, pools, "a", "b", 100, ExactIn, DefaultOptions())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if result.RouteArr() != "a:b:500" || result.QuoteArr() != "100" {
+			t.Fatalf("got %s / %s", result.RouteArr(), result.QuoteArr())
+		}
+	})
+
+	t.Run("large swap is split across pools", func(t *testing.T) {
+		result, err := FindBestRoute(quoter, pools, "a", "b", 500_000, ExactIn, DefaultOptions())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(result.Routes) < 2 {
+			t.Fatalf("expected a split, got %s / %s", result.RouteArr(), result.QuoteArr())
+		}
+
+		sum := 0
+		for _, quote := range result.Quotes {
+			sum += quote
+		}
+		if sum != 100 {
+			t.Fatalf("quotes sum to %d", sum)
+		}
+
+		single, ok := simulateRoute(quoter, ExactIn, "a:b:500", 500_000)
+		if !ok {
+			t.Fatal("single route simulation failed")
+		}
+
+		if result.AmountIn != 500_000 || result.AmountOut <= single.amountOut {
+			t.Fatalf("split %+v does not beat single route %+v", result, single)
+		}
+	})
+
+	t.Run("exact out", func(t *testing.T) {
+		result, err := FindBestRoute(quoter, pools, "c", "a", 100_000, ExactOut, DefaultOptions())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if result.AmountOut != 100_000 {
+			t.Fatalf("amount out %d, want 100000", result.AmountOut)
+		}
+
+		direct, ok := simulateRoute(quoter, ExactOut, "c:a:500", 100_000)
+		if !ok {
+			t.Fatal("direct route simulation failed")
+		}
+
+		if result.AmountIn > direct.amountIn {
+			t.Fatalf("amount in %d, direct route needs %d", result.AmountIn, direct.amountIn)
+		}
+	})
+
+	t.Run("max routes", func(t *testing.T) {
+		opts := DefaultOptions()
+		opts.MaxRoutes = 1
+
+		result, err := FindBestRoute(quoter, pools, "a", "b", 500_000, ExactIn, opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(result.Routes) != 1 || result.QuoteArr() != "100" {
+			t.Fatalf("got %s / %s", result.RouteArr(), result.QuoteArr())
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			tokenIn  string
+			tokenOut string
+			amount   int64
+			swapType SwapType
+			opts     Options
+			expected error
+		}{
+			{"no route", "a", "d", 100, ExactIn, DefaultOptions(), ErrNoRoute},
+			{"same token", "a", "a", 100, ExactIn, DefaultOptions(), ErrSameToken},
+			{"zero amount", "a", "b", 0, ExactIn, DefaultOptions(), ErrInvalidAmount},
+			{"swap type", "a", "b", 100, SwapType("EXACT"), DefaultOptions(), ErrInvalidSwapType},
+			{"hops", "a", "b", 100, ExactIn, Options{MaxHops: 4, MaxRoutes: 7, MaxCandidates: 10, SplitStep: 10}, ErrInvalidOptions},
+			{"split step", "a", "b", 100, ExactIn, Options{MaxHops: 3, MaxRoutes: 7, MaxCandidates: 10, SplitStep: 30}, ErrInvalidOptions},
+			{"not enough liquidity", "a", "b", 50_000_000, ExactOut, DefaultOptions(), ErrNoRoute},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				_, err := FindBestRoute(quoter, pools, tt.tokenIn, tt.tokenOut, tt.amount, tt.swapType, tt.opts)
+				if !errors.Is(err, tt.expected) {
+					t.Fatalf("error = %v, want %v", err, tt.expected)
+				}
+			})
+		}
+	})
+}